@@ -0,0 +1,141 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// namedKey qualifies a binding by an explicit name rather than by its reflect.Type,
+// letting multiple competing implementations of the same interface coexist
+type namedKey struct {
+	name string
+}
+
+// BindNamed registers a singleton under an explicit name instead of its reflect.Type.
+// Use GetNamed, or the `inject:"name"` struct tag, to resolve it later
+// initialize func(...) (value, error)
+func (c *Container) BindNamed(name string, initialize interface{}) error {
+	return c.BindWithKey(namedKey{name}, initialize, false)
+}
+
+// PrototypeNamed registers a prototype under an explicit name, see BindNamed
+// initialize func(...) (value, error)
+func (c *Container) PrototypeNamed(name string, initialize interface{}) error {
+	return c.BindWithKey(namedKey{name}, initialize, true)
+}
+
+// GetNamed resolves a binding previously registered via BindNamed/PrototypeNamed
+func (c *Container) GetNamed(name string) (interface{}, error) {
+	return c.Get(namedKey{name})
+}
+
+// BindInterface registers initialize under the interface type pointed to by ifacePtr,
+// which should be passed as a nil pointer of the interface, e.g. (*MyInterface)(nil).
+// Unlike Bind (which keys on the initialize function's concrete return type), this
+// makes the interface itself the lookup key, so Get/Call resolve it in O(1) instead
+// of relying on the best-effort AssignableTo scan for every binding satisfying it
+// initialize func(...) (value, error)
+func (c *Container) BindInterface(ifacePtr interface{}, initialize interface{}) error {
+	iface, err := ifaceTypeOf(ifacePtr)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.ValueOf(initialize).IsValid() {
+		return ErrInvalidArgs("initialize is nil")
+	}
+
+	initializeType := reflect.ValueOf(initialize).Type()
+	if initializeType.NumOut() <= 0 {
+		return ErrInvalidArgs("expect func return values count greater than 0, but got 0")
+	}
+
+	if !initializeType.Out(0).Implements(iface) {
+		return ErrInvalidArgs(fmt.Sprintf("initialize's return type does not implement %s", iface))
+	}
+
+	return c.bindWith(iface, iface, initialize, false)
+}
+
+// GetAll returns every binding in this container and its ancestors whose concrete
+// type is assignable to the interface pointed to by ifacePtr (same calling convention
+// as BindInterface), enabling plugin-style enumeration of every registered implementation
+func (c *Container) GetAll(ifacePtr interface{}) ([]interface{}, error) {
+	iface, err := ifaceTypeOf(ifacePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []interface{}
+	for cur := c; cur != nil; cur = cur.parent {
+		for _, obj := range cur.objectSlices {
+			if !obj.typ.AssignableTo(iface) {
+				continue
+			}
+
+			val, err := obj.Value()
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, val)
+		}
+	}
+
+	return results, nil
+}
+
+func ifaceTypeOf(ifacePtr interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(ifacePtr)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		return nil, ErrInvalidArgs("expect a nil pointer to an interface, e.g. (*MyInterface)(nil)")
+	}
+
+	return t.Elem(), nil
+}
+
+// hasInjectTag reports whether t declares at least one field tagged with `inject`
+func hasInjectTag(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("inject"); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// injectStruct builds a new value of t, resolving every `inject`-tagged field from
+// the container: an empty tag value resolves by the field's type, a non-empty one
+// resolves the named binding registered via BindNamed/PrototypeNamed
+func (c *Container) injectStruct(t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, ok := field.Tag.Lookup("inject")
+		if !ok {
+			continue
+		}
+
+		var (
+			val interface{}
+			err error
+		)
+
+		if name == "" {
+			val, err = c.Get(field.Type)
+		} else {
+			val, err = c.GetNamed(name)
+		}
+
+		if err != nil {
+			return reflect.Value{}, ErrArgNotInstanced(err.Error())
+		}
+
+		v.Field(i).Set(reflect.ValueOf(val))
+	}
+
+	return v, nil
+}
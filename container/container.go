@@ -2,6 +2,7 @@ package container
 
 import (
 	"fmt"
+	"io"
 	"reflect"
 	"sync"
 )
@@ -22,6 +23,16 @@ type Entity struct {
 
 // Value instance value if not initiailzed
 func (e *Entity) Value() (interface{}, error) {
+	// The cycle guard must sit here, before the singleton lock below is acquired -
+	// a cycle of singletons (A -> B -> A) re-enters Value() on A a second time on
+	// the same goroutine while A's own e.lock is still held by the outer call, which
+	// would deadlock on the mutex before createValue ever got a chance to detect it
+	cyclePath, cyclic := pushResolution(e.key)
+	if cyclic {
+		return nil, ErrCycleDetected(formatKeyPath(cyclePath))
+	}
+	defer popResolution()
+
 	if e.prototype {
 		return e.createValue()
 	}
@@ -69,6 +80,19 @@ type Container struct {
 
 	objects      map[interface{}]*Entity
 	objectSlices []*Entity
+
+	// typeIndex provides O(1) lookup by reflect.Type for bindings registered with
+	// a type key (Bind, BindWithKey when key is a reflect.Type, BindInterface), so
+	// Get doesn't have to fall back to a linear AssignableTo scan in the common case
+	typeIndex map[reflect.Type]*Entity
+
+	// edges records, for every bound key, the reflect.Type of each argument its
+	// initialize func declares - i.e. the keys it depends on. Populated by bindWith
+	// and walked by Validate to detect cycles and missing bindings ahead of time
+	edges map[interface{}][]reflect.Type
+
+	// parent is the container this one was derived from via NewChild, nil for a root container
+	parent *Container
 }
 
 // New create a new container
@@ -76,9 +100,54 @@ func New() *Container {
 	return &Container{
 		objects:      make(map[interface{}]*Entity),
 		objectSlices: make([]*Entity, 0),
+		typeIndex:    make(map[reflect.Type]*Entity),
+		edges:        make(map[interface{}][]reflect.Type),
+	}
+}
+
+// NewChild creates a scoped child container. Bindings registered on the child
+// shadow the ones on its parent, while Get falls back to walking up the parent
+// chain when a binding can't be found locally. This is typically used to carry
+// request-scoped values (auth principal, tenant id, request id, ...) without
+// polluting the long-lived root container
+func (c *Container) NewChild() *Container {
+	return &Container{
+		objects:      make(map[interface{}]*Entity),
+		objectSlices: make([]*Entity, 0),
+		typeIndex:    make(map[reflect.Type]*Entity),
+		edges:        make(map[interface{}][]reflect.Type),
+		parent:       c,
 	}
 }
 
+// Close shuts down the container, invoking Close on every already-instantiated
+// singleton that implements io.Closer, in the reverse order bindings were
+// registered so dependents are closed before their dependencies. It does not
+// propagate to the parent container - each container owns only what it created
+func (c *Container) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var firstErr error
+	for i := len(c.objectSlices) - 1; i >= 0; i-- {
+		entity := c.objectSlices[i]
+		if entity.prototype || entity.value == nil {
+			continue
+		}
+
+		closer, ok := entity.value.(io.Closer)
+		if !ok {
+			continue
+		}
+
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
 // Must if err is not nil, panic it
 func (c *Container) Must(err error) {
 	if err != nil {
@@ -136,6 +205,10 @@ func (c *Container) BindValue(key interface{}, value interface{}) error {
 	c.objects[key] = &entity
 	c.objectSlices = append(c.objectSlices, &entity)
 
+	if typ, ok := key.(reflect.Type); ok {
+		c.typeIndex[typ] = &entity
+	}
+
 	return nil
 }
 
@@ -205,17 +278,27 @@ func (c *Container) Get(key interface{}) (interface{}, error) {
 		keyReflectType = reflect.TypeOf(key)
 	}
 
-	for _, obj := range c.objectSlices {
+	c.lock.RLock()
+	entity, ok := c.objects[key]
+	if !ok {
+		entity, ok = c.typeIndex[keyReflectType]
+	}
+	c.lock.RUnlock()
 
-		if obj.key == key || obj.key == keyReflectType {
-			return obj.Value()
-		}
+	if ok {
+		return entity.Value()
+	}
 
+	for _, obj := range c.objectSlices {
 		if obj.typ.AssignableTo(keyReflectType) {
 			return obj.Value()
 		}
 	}
 
+	if c.parent != nil {
+		return c.parent.Get(key)
+	}
+
 	return nil, ErrObjectNotFound(fmt.Sprintf("key=%s", key))
 }
 
@@ -250,6 +333,18 @@ func (c *Container) bindWith(key interface{}, typ reflect.Type, initialize inter
 	c.objects[key] = &entity
 	c.objectSlices = append(c.objectSlices, &entity)
 
+	if keyType, ok := key.(reflect.Type); ok {
+		c.typeIndex[keyType] = &entity
+	}
+
+	initializeType := reflect.TypeOf(initialize)
+	depTypes := make([]reflect.Type, initializeType.NumIn())
+	for i := range depTypes {
+		depTypes[i] = initializeType.In(i)
+	}
+
+	c.edges[key] = depTypes
+
 	return nil
 }
 
@@ -258,6 +353,17 @@ func (c *Container) funcArgs(t reflect.Type) ([]reflect.Value, error) {
 	argValues := make([]reflect.Value, argsSize)
 	for i := 0; i < argsSize; i++ {
 		argType := t.In(i)
+
+		if argType.Kind() == reflect.Struct && hasInjectTag(argType) {
+			val, err := c.injectStruct(argType)
+			if err != nil {
+				return argValues, err
+			}
+
+			argValues[i] = val
+			continue
+		}
+
 		val, err := c.instanceOfType(argType)
 		if err != nil {
 			return argValues, err
@@ -0,0 +1,182 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// resolutionStacks tracks, per goroutine, the chain of keys currently being
+// resolved by Entity.Value -> createValue -> funcArgs -> Get -> Value ... . It
+// turns a circular dependency from a deadlock/stack overflow into a descriptive
+// error. Go has no public goroutine-local storage, so goroutineID() below reads
+// the id straight out of the runtime-provided stack trace as a stand-in key
+var resolutionStacks sync.Map // goroutine id (uint64) -> []interface{}
+
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+
+	fields := strings.Fields(strings.TrimPrefix(string(buf[:n]), "goroutine "))
+	if len(fields) == 0 {
+		return 0
+	}
+
+	id, _ := strconv.ParseUint(fields[0], 10, 64)
+
+	return id
+}
+
+// pushResolution records that key is about to be resolved on the current goroutine.
+// If key is already on the stack, it returns the full cycle (existing stack + key)
+// and cyclic=true instead of pushing, so the caller can fail instead of recursing forever
+func pushResolution(key interface{}) (stack []interface{}, cyclic bool) {
+	gid := goroutineID()
+
+	var existing []interface{}
+	if v, ok := resolutionStacks.Load(gid); ok {
+		existing = v.([]interface{})
+	}
+
+	for _, k := range existing {
+		if k == key {
+			return append(append([]interface{}{}, existing...), key), true
+		}
+	}
+
+	resolutionStacks.Store(gid, append(existing, key))
+
+	return nil, false
+}
+
+// popResolution removes the most recently pushed key for the current goroutine
+func popResolution() {
+	gid := goroutineID()
+
+	v, ok := resolutionStacks.Load(gid)
+	if !ok {
+		return
+	}
+
+	stack := v.([]interface{})
+	if len(stack) <= 1 {
+		resolutionStacks.Delete(gid)
+		return
+	}
+
+	resolutionStacks.Store(gid, stack[:len(stack)-1])
+}
+
+// Validate performs an eager, static analysis of the dependency graph built up
+// by Bind/BindWithKey/BindInterface: a topological walk reports circular
+// dependencies with their full path (A -> B -> C -> A), dependencies that are
+// never bound, and singletons that depend on a prototype binding - the
+// prototype's first instantiation ends up cached forever inside the singleton,
+// which defeats the point of declaring it a prototype
+func (c *Container) Validate() error {
+	var problems []string
+
+	visiting := map[interface{}]bool{}
+	visited := map[interface{}]bool{}
+	var path []interface{}
+
+	var visit func(entity *Entity)
+	visit = func(entity *Entity) {
+		if visited[entity.key] {
+			return
+		}
+
+		if visiting[entity.key] {
+			problems = append(problems, fmt.Sprintf("circular dependency: %s", formatKeyPath(append(append([]interface{}{}, path...), entity.key))))
+			return
+		}
+
+		visiting[entity.key] = true
+		path = append(path, entity.key)
+
+		for _, depType := range c.edges[entity.key] {
+			if reflect.TypeOf(c).AssignableTo(depType) {
+				continue
+			}
+
+			dep := c.findEntityForType(depType)
+			if dep == nil {
+				problems = append(problems, fmt.Sprintf("missing dependency: %s requires %s, which is not bound", formatKey(entity.key), depType))
+				continue
+			}
+
+			if !entity.prototype && dep.prototype {
+				problems = append(problems, fmt.Sprintf("prototype-into-singleton: singleton %s depends on prototype %s, the prototype will only ever be created once and cached inside the singleton", formatKey(entity.key), formatKey(dep.key)))
+			}
+
+			visit(dep)
+		}
+
+		path = path[:len(path)-1]
+		visiting[entity.key] = false
+		visited[entity.key] = true
+	}
+
+	for _, entity := range c.objectSlices {
+		visit(entity)
+	}
+
+	if len(problems) > 0 {
+		return ErrGraphInvalid(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// findEntityForType looks up the entity bound for t without instantiating it,
+// checking this container first and then walking up to the parent chain
+func (c *Container) findEntityForType(t reflect.Type) *Entity {
+	if entity, ok := c.typeIndex[t]; ok {
+		return entity
+	}
+
+	for _, obj := range c.objectSlices {
+		if obj.typ.AssignableTo(t) {
+			return obj
+		}
+	}
+
+	if c.parent != nil {
+		return c.parent.findEntityForType(t)
+	}
+
+	return nil
+}
+
+func formatKey(key interface{}) string {
+	if t, ok := key.(reflect.Type); ok {
+		return t.String()
+	}
+
+	return fmt.Sprintf("%v", key)
+}
+
+func formatKeyPath(path []interface{}) string {
+	names := make([]string, len(path))
+	for i, key := range path {
+		names[i] = formatKey(key)
+	}
+
+	return strings.Join(names, " -> ")
+}
+
+// ErrCycleDetected is an error object representing a circular dependency found
+// at resolve time on the actual call stack. Validate reports the cycles it finds
+// ahead of time through ErrGraphInvalid instead
+func ErrCycleDetected(msg string) error {
+	return fmt.Errorf("circular dependency detected: %s", msg)
+}
+
+// ErrGraphInvalid is an error object aggregating every problem Validate found
+// in the dependency graph
+func ErrGraphInvalid(msg string) error {
+	return fmt.Errorf("invalid dependency graph: %s", msg)
+}
@@ -0,0 +1,84 @@
+package container
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type diamondA struct{ B *diamondB }
+type diamondB struct{ C *diamondC }
+type diamondC struct{}
+
+func TestValidateDiamondDependency(t *testing.T) {
+	c := New()
+
+	c.Must(c.Singleton(func() *diamondC { return &diamondC{} }))
+	c.Must(c.Singleton(func(dep *diamondC) *diamondB { return &diamondB{C: dep} }))
+	c.Must(c.Singleton(func(b *diamondB, dep *diamondC) *diamondA { return &diamondA{B: b} }))
+
+	if err := c.Validate(); err != nil {
+		t.Fatalf("expected diamond dependency graph to validate, got: %s", err)
+	}
+}
+
+type cyclicA struct{}
+type cyclicB struct{}
+
+func TestValidateDetectsCycle(t *testing.T) {
+	c := New()
+
+	c.Must(c.Singleton(func(b *cyclicB) *cyclicA { return &cyclicA{} }))
+	c.Must(c.Singleton(func(a *cyclicA) *cyclicB { return &cyclicB{} }))
+
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("expected Validate to report a circular dependency")
+	}
+
+	if !strings.Contains(err.Error(), "circular dependency") {
+		t.Fatalf("expected error to mention circular dependency, got: %s", err)
+	}
+}
+
+func TestValidatePrototypeIntoSingleton(t *testing.T) {
+	c := New()
+
+	c.Must(c.Prototype(func() *cyclicA { return &cyclicA{} }))
+	c.Must(c.Singleton(func(p *cyclicA) *cyclicB { return &cyclicB{} }))
+
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("expected Validate to flag a singleton depending on a prototype")
+	}
+
+	if !strings.Contains(err.Error(), "prototype-into-singleton") {
+		t.Fatalf("expected error to mention prototype-into-singleton, got: %s", err)
+	}
+}
+
+// TestGetSingletonCycleFailsInsteadOfDeadlocking reproduces the backlog's reported
+// failure mode: two mutually-dependent singletons must fail resolution instead of
+// hanging forever on Entity.lock.
+func TestGetSingletonCycleFailsInsteadOfDeadlocking(t *testing.T) {
+	c := New()
+
+	c.Must(c.Singleton(func(b *cyclicB) *cyclicA { return &cyclicA{} }))
+	c.Must(c.Singleton(func(a *cyclicA) *cyclicB { return &cyclicB{} }))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Get(reflect.TypeOf(&cyclicA{}))
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected an error for a circular singleton dependency")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Get deadlocked on a circular singleton dependency")
+	}
+}
@@ -0,0 +1,343 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// pathParamsKey 是请求路径参数写入 http.Request context 时使用的 key，
+// 由路由层在匹配到带参数的路径时注入，Bind 通过 path 标签读取这里的值
+type pathParamsKey struct{}
+
+// PathParams 是从 URL 路径中解析出来的参数集合
+type PathParams map[string]string
+
+// WithPathParams 将解析出的路径参数注入请求上下文，供 Bind 的 path 标签使用
+func (ctx *WebContext) WithPathParams(params PathParams) {
+	ctx.Request.r = ctx.Request.r.WithContext(context.WithValue(ctx.Request.r.Context(), pathParamsKey{}, params))
+}
+
+// BindError 表示请求绑定或校验失败，Resolve/handler 可以将其转换为 400 响应
+type BindError struct {
+	msg string
+}
+
+func (e *BindError) Error() string { return e.msg }
+
+// ErrBind 创建一个 BindError
+func ErrBind(format string, args ...interface{}) error {
+	return &BindError{msg: fmt.Sprintf(format, args...)}
+}
+
+// IsBindError 判断 err 是否由 Bind 产生
+func IsBindError(err error) bool {
+	_, ok := err.(*BindError)
+	return ok
+}
+
+// Bind 根据请求的 Content-Type 解析请求体，再结合 query/path/header 标签补齐
+// 其余字段，最后执行 validate 标签声明的校验规则。支持的来源标签：
+// json、xml、form（含 multipart/form-data 与 x-www-form-urlencoded）、query、path、header
+func (ctx *WebContext) Bind(target interface{}) error {
+	return ctx.Request.Bind(target)
+}
+
+// Bind 是 WebContext.Bind 的底层实现，直接作用于 Request
+func (req *Request) Bind(target interface{}) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrBind("bind target must be a non-nil pointer")
+	}
+
+	if err := req.bindBody(target); err != nil {
+		return err
+	}
+
+	elem := rv.Elem()
+	if elem.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if err := req.bindFields(elem); err != nil {
+		return err
+	}
+
+	return validateStruct(elem)
+}
+
+func (req *Request) contentType() string {
+	ct := req.r.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return ct
+	}
+
+	return mediaType
+}
+
+// bindBody 根据 Content-Type 把请求体整体反序列化到 target，GET 请求或者
+// 未知的 Content-Type 会被忽略，后续字段绑定仍然可以从 query/path/header 取值
+func (req *Request) bindBody(target interface{}) error {
+	switch req.contentType() {
+	case "application/json":
+		if req.r.Body == nil || req.r.ContentLength == 0 {
+			return nil
+		}
+
+		dec := json.NewDecoder(req.r.Body)
+		if err := dec.Decode(target); err != nil {
+			return ErrBind("decode json body failed: %s", err)
+		}
+
+	case "application/xml", "text/xml":
+		if req.r.Body == nil || req.r.ContentLength == 0 {
+			return nil
+		}
+
+		dec := xml.NewDecoder(req.r.Body)
+		if err := dec.Decode(target); err != nil {
+			return ErrBind("decode xml body failed: %s", err)
+		}
+
+	case "multipart/form-data":
+		if err := req.r.ParseMultipartForm(32 << 20); err != nil {
+			return ErrBind("parse multipart form failed: %s", err)
+		}
+
+	case "application/x-www-form-urlencoded":
+		if err := req.r.ParseForm(); err != nil {
+			return ErrBind("parse form failed: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// bindFields 遍历结构体字段，依次尝试 path、header、form、query 标签，命中的
+// 第一个来源生效。指向结构体的指针字段与匿名嵌入的结构体字段会被递归处理，
+// 具名的非指针结构体字段不会被递归
+func (req *Request) bindFields(v reflect.Value) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+
+		// 匿名嵌入的结构体即使类型未导出（CanSet 为 false），其导出字段仍然可写，
+		// 因此需要在 CanSet 检查之前递归
+		if fv.Kind() == reflect.Struct && field.Anonymous {
+			if err := req.bindFields(fv); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !fv.CanSet() {
+			continue
+		}
+
+		if fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+
+			if err := req.bindFields(fv.Elem()); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		raw, ok := req.lookupValue(field)
+		if !ok {
+			continue
+		}
+
+		if err := setFieldValue(fv, raw); err != nil {
+			return ErrBind("field %s: %s", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (req *Request) lookupValue(field reflect.StructField) (string, bool) {
+	if name, ok := field.Tag.Lookup("path"); ok {
+		if params := req.r.Context().Value(pathParamsKey{}); params != nil {
+			if v, ok := params.(PathParams)[name]; ok {
+				return v, true
+			}
+		}
+	}
+
+	if name, ok := field.Tag.Lookup("header"); ok {
+		if v := req.r.Header.Get(name); v != "" {
+			return v, true
+		}
+	}
+
+	if name, ok := field.Tag.Lookup("form"); ok {
+		if v := req.r.FormValue(name); v != "" {
+			return v, true
+		}
+	}
+
+	if name, ok := field.Tag.Lookup("query"); ok {
+		if v := req.r.URL.Query().Get(name); v != "" {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+func setFieldValue(fv reflect.Value, raw string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+
+		fv.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		fv.SetBool(b)
+	case reflect.Slice:
+		parts := strings.Split(raw, ",")
+		slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setFieldValue(slice.Index(i), part); err != nil {
+				return err
+			}
+		}
+
+		fv.Set(slice)
+	default:
+		return fmt.Errorf("unsupported field kind: %s", fv.Kind())
+	}
+
+	return nil
+}
+
+// validateStruct 校验结构体字段上的 validate 标签，目前支持 required 与 min。
+// 递归规则与 bindFields 保持一致：嵌套的指针结构体与匿名结构体字段也会被
+// 校验，否则会出现字段确实被 bindFields 填充了、但其自身的 validate 标签
+// 从未被执行的情况
+func validateStruct(v reflect.Value) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+
+		if fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				continue
+			}
+
+			if err := validateStruct(fv.Elem()); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if fv.Kind() == reflect.Struct && field.Anonymous {
+			if err := validateStruct(fv); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		rules, ok := field.Tag.Lookup("validate")
+		if !ok {
+			continue
+		}
+
+		for _, rule := range strings.Split(rules, ",") {
+			if err := applyValidateRule(field.Name, fv, rule); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func applyValidateRule(fieldName string, fv reflect.Value, rule string) error {
+	name := rule
+	param := ""
+
+	if idx := strings.Index(rule, "="); idx >= 0 {
+		name = rule[:idx]
+		param = rule[idx+1:]
+	}
+
+	switch name {
+	case "required":
+		if isZeroValue(fv) {
+			return ErrBind("field %s is required", fieldName)
+		}
+	case "min":
+		min, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return ErrBind("field %s: invalid min rule %q", fieldName, param)
+		}
+
+		if numericValue(fv) < min {
+			return ErrBind("field %s must be >= %s", fieldName, param)
+		}
+	}
+
+	return nil
+}
+
+func isZeroValue(fv reflect.Value) bool {
+	return fv.IsZero()
+}
+
+func numericValue(fv reflect.Value) float64 {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return fv.Float()
+	case reflect.String:
+		return float64(len(fv.String()))
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(fv.Len())
+	default:
+		return 0
+	}
+}
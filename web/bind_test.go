@@ -0,0 +1,111 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// structValue copies v onto the heap and returns an addressable reflect.Value
+// pointing at it, matching the shape validateStruct/bindFields expect.
+func structValue(v interface{}) reflect.Value {
+	rv := reflect.ValueOf(v)
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+
+	return ptr.Elem()
+}
+
+func TestBindFieldsReadsQueryPathAndHeaderTags(t *testing.T) {
+	type dto struct {
+		ID    string `path:"id"`
+		Name  string `query:"name"`
+		Token string `header:"X-Token"`
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/users/42?name=alice", nil)
+	r.Header.Set("X-Token", "secret")
+
+	req := &Request{r: r}
+
+	var d dto
+	ctx := &WebContext{Request: req}
+	ctx.WithPathParams(PathParams{"id": "42"})
+
+	if err := ctx.Request.Bind(&d); err != nil {
+		t.Fatalf("unexpected bind error: %s", err)
+	}
+
+	if d.ID != "42" || d.Name != "alice" || d.Token != "secret" {
+		t.Fatalf("unexpected bind result: %+v", d)
+	}
+}
+
+func TestBindFieldsRecursesIntoNestedStructs(t *testing.T) {
+	type inner struct {
+		Name string `query:"name"`
+	}
+
+	type outer struct {
+		inner
+		Page *struct {
+			Size string `query:"size"`
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/?name=bob&size=10", nil)
+	req := &Request{r: r}
+
+	var o outer
+	if err := req.Bind(&o); err != nil {
+		t.Fatalf("unexpected bind error: %s", err)
+	}
+
+	if o.Name != "bob" {
+		t.Fatalf("expected anonymous nested field to bind, got %+v", o)
+	}
+
+	if o.Page == nil || o.Page.Size != "10" {
+		t.Fatalf("expected nested pointer struct to bind, got %+v", o.Page)
+	}
+}
+
+func TestValidateStructRequiredAndMin(t *testing.T) {
+	type dto struct {
+		Name string `validate:"required"`
+		Age  int    `validate:"min=18"`
+	}
+
+	if err := validateStruct(structValue(dto{})); err == nil {
+		t.Fatal("expected required field to fail validation when empty")
+	}
+
+	if err := validateStruct(structValue(dto{Name: "alice", Age: 10})); err == nil {
+		t.Fatal("expected min rule to reject a value below the threshold")
+	}
+
+	if err := validateStruct(structValue(dto{Name: "alice", Age: 18})); err != nil {
+		t.Fatalf("expected a valid struct to pass validation, got %s", err)
+	}
+}
+
+func TestValidateStructRecursesIntoNestedStructs(t *testing.T) {
+	type inner struct {
+		Name string `validate:"required"`
+	}
+
+	type outer struct {
+		Inner *inner
+	}
+
+	o := outer{Inner: &inner{}}
+	if err := validateStruct(structValue(o)); err == nil {
+		t.Fatal("expected validateStruct to recurse into a nested pointer struct, matching bindFields")
+	}
+
+	o.Inner.Name = "set"
+	if err := validateStruct(structValue(o)); err != nil {
+		t.Fatalf("expected nested struct with required field set to pass, got %s", err)
+	}
+}
@@ -35,15 +35,30 @@ func NewWebHandler(c *container.Container, handler WebHandler, decors ...Handler
 	}
 }
 
-// ServeHTTP 实现http.HandlerFunc接口
+// ServeHTTP 实现http.HandlerFunc接口，不带路径参数，等价于 serve(w, r, nil)
 func (h webHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, nil)
+}
+
+// serve 是实际处理请求的地方，params 是路由层（Router.match）解析出来的路径参数，
+// 直接调用 ServeHTTP 时（未经过 Router）没有这部分信息，传 nil 即可
+func (h webHandler) serve(w http.ResponseWriter, r *http.Request, params PathParams) {
+	// 每个请求使用独立的子容器，用来承载鉴权用户、租户 ID、请求 ID 等
+	// 请求级别的数据，请求结束后随容器一起释放，不会污染长期存活的根容器
+	requestContainer := h.container.NewChild()
+	defer requestContainer.Close()
+
 	context := &WebContext{
 		Response: &Response{
 			w:       w,
 			headers: make(map[string]string),
 		},
 		Request:   &Request{r: r},
-		Container: h.container,
+		Container: requestContainer,
+	}
+
+	if params != nil {
+		context.WithPathParams(params)
 	}
 
 	resp := h.handle(context)
@@ -101,6 +116,14 @@ func (ctx *WebContext) Resolve(callback interface{}) HTTPResponse {
 		return ctx.NewHTMLResponse("")
 	}
 
+	if last, ok := results[len(results)-1].(error); ok && last != nil {
+		if IsBindError(last) {
+			return ctx.NewErrorResponse(last.Error(), http.StatusBadRequest)
+		}
+
+		return ctx.NewErrorResponse(last.Error(), http.StatusInternalServerError)
+	}
+
 	resp, ok := results[0].(HTTPResponse)
 	if ok {
 		return resp
@@ -0,0 +1,74 @@
+package web
+
+import (
+	"reflect"
+
+	"github.com/mylxsw/go-toolkit/container"
+)
+
+// containerType 是 *container.Container 自身的 reflect.Type，用于在扫描回调参数
+// 时跳过容器本身，它由 container.Call 负责注入，不是一个请求 DTO
+var containerType = reflect.TypeOf((*container.Container)(nil))
+
+// bindRequestDTOs 让 Router.Inject 注册的回调可以像普通 WebHandler 里手写
+// ctx.Bind(&dto) 一样，直接以 struct 参数的形式声明请求 DTO：回调的每一个
+// struct（或 *struct）参数，如果容器里还没有对应的绑定，就会被当作请求 DTO
+// 处理——按 bind 标签从请求中解析出来，再注册进当前请求的子容器，这样
+// container.Call 才能在 funcArgs 里把它解析出来喂给回调。已经声明了
+// inject 标签的参数走的是 chunk0-5 的依赖注入模式，这里不处理
+func bindRequestDTOs(ctx *WebContext, callback interface{}) error {
+	t := reflect.TypeOf(callback)
+	if t == nil || t.Kind() != reflect.Func {
+		return nil
+	}
+
+	for i := 0; i < t.NumIn(); i++ {
+		argType := t.In(i)
+		if argType == containerType {
+			continue
+		}
+
+		ptr := argType.Kind() == reflect.Ptr
+		structType := argType
+		if ptr {
+			structType = argType.Elem()
+		}
+
+		if structType.Kind() != reflect.Struct || structHasInjectTag(structType) {
+			continue
+		}
+
+		if _, err := ctx.Container.Get(argType); err == nil {
+			continue // already resolvable, e.g. bound explicitly as a singleton
+		}
+
+		instance := reflect.New(structType)
+		if err := ctx.Bind(instance.Interface()); err != nil {
+			return err
+		}
+
+		if ptr {
+			if err := ctx.Container.BindValue(argType, instance.Interface()); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := ctx.Container.BindValue(structType, instance.Elem().Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func structHasInjectTag(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("inject"); ok {
+			return true
+		}
+	}
+
+	return false
+}
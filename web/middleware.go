@@ -0,0 +1,267 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mylxsw/go-toolkit/container"
+)
+
+// Middleware 定义了请求处理过程中的生命周期钩子，相比 HandlerDecorator
+// 提供了更细粒度的切入点：Before 在 handler 执行前运行，返回 error 会
+// 中断后续流程并转换为错误响应；Around 包裹 next handler，既可以在调用
+// 前后插入逻辑，也可以完全接管请求（比如 WebSocket 升级）；After 在拿到
+// 最终响应之后运行，可以用来修改响应头或者替换响应本身
+type Middleware interface {
+	Before(ctx *WebContext) error
+	Around(next WebHandler) WebHandler
+	After(ctx *WebContext, resp HTTPResponse) HTTPResponse
+}
+
+// BaseMiddleware 提供 Middleware 接口的默认空实现，具体的中间件可以
+// 匿名嵌入该结构体，只需要覆盖自己关心的钩子即可
+type BaseMiddleware struct{}
+
+// Before 默认不做任何处理
+func (BaseMiddleware) Before(ctx *WebContext) error { return nil }
+
+// Around 默认直接透传给下一个 handler
+func (BaseMiddleware) Around(next WebHandler) WebHandler { return next }
+
+// After 默认原样返回响应
+func (BaseMiddleware) After(ctx *WebContext, resp HTTPResponse) HTTPResponse { return resp }
+
+// applyMiddlewares 按照声明顺序将中间件应用到 handler 上，构造出最终的调用链，
+// 列表中靠前的中间件位于调用链的最外层
+func applyMiddlewares(handler WebHandler, mws []Middleware) WebHandler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		mw := mws[i]
+		next := handler
+		handler = func(ctx *WebContext) HTTPResponse {
+			if err := mw.Before(ctx); err != nil {
+				return ctx.NewErrorResponse(err.Error(), statusCodeOf(err))
+			}
+
+			resp := mw.Around(next)(ctx)
+			return mw.After(ctx, resp)
+		}
+	}
+
+	return handler
+}
+
+// HTTPStatusError 可以由 Middleware.Before 返回的 error 实现，用来指定该错误
+// 被转换成响应时使用的状态码，而不是一律被归为 400 Bad Request，例如
+// RateLimitMiddleware 就实现了它，使被限流的请求返回 429 而不是 400
+type HTTPStatusError interface {
+	error
+	StatusCode() int
+}
+
+func statusCodeOf(err error) int {
+	if se, ok := err.(HTTPStatusError); ok {
+		return se.StatusCode()
+	}
+
+	return http.StatusBadRequest
+}
+
+// Router 提供路由分组与中间件能力，并在标准库 http.ServeMux 无法做到的
+// 地方——形如 /users/:id 或 /users/{id} 的路径参数——自己做分段匹配，匹配
+// 出的参数通过 ctx.WithPathParams 注入请求，供 Bind 的 path 标签使用。
+// 分组之间通过前缀与中间件列表的继承来组合，Group 返回的是一个新的
+// Router，不会影响父级分组
+type Router struct {
+	container   *container.Container
+	prefix      string
+	middlewares []Middleware
+	routes      *[]*RouteInfo
+}
+
+// NewRouter 创建一个根 Router，所有注册的路由信息都汇总到同一个列表中，
+// 供匹配请求与 OpenAPI 文档生成使用
+func NewRouter(c *container.Container) *Router {
+	routes := make([]*RouteInfo, 0)
+
+	return &Router{
+		container: c,
+		routes:    &routes,
+	}
+}
+
+// ServeHTTP 实现 http.Handler 接口，使 Router 本身可以直接作为 server 的 handler
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	route, params, ok := r.match(req.Method, req.URL.Path)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	route.handler.serve(w, req, params)
+}
+
+// match 在已注册的全部路由中查找与 method、path 匹配的第一个，命中时一并
+// 返回从路径中解析出的参数
+func (r *Router) match(method string, path string) (*RouteInfo, PathParams, bool) {
+	segments := splitPath(path)
+
+	for _, route := range *r.routes {
+		if route.Method != method {
+			continue
+		}
+
+		if params, ok := matchSegments(route.segments, segments); ok {
+			return route, params, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// splitPath 把形如 /users/42 的路径拆分成 ["users", "42"]，忽略首尾的斜杠
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, "/")
+}
+
+// paramName 判断一个路径模板分段是否是形如 :id 或 {id} 的参数占位符
+func paramName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, ":") {
+		return segment[1:], true
+	}
+
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+
+	return "", false
+}
+
+// matchSegments 按分段比较路径模板与实际请求路径，模板中的 :name / {name}
+// 分段会被捕获进返回的 PathParams，其余分段必须逐字相等
+func matchSegments(template []string, path []string) (PathParams, bool) {
+	if len(template) != len(path) {
+		return nil, false
+	}
+
+	params := PathParams{}
+	for i, segment := range template {
+		if name, ok := paramName(segment); ok {
+			params[name] = path[i]
+			continue
+		}
+
+		if segment != path[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// Group 基于当前 Router 创建一个路径前缀与中间件都被继承的子分组
+func (r *Router) Group(prefix string, mws ...Middleware) *Router {
+	middlewares := make([]Middleware, 0, len(r.middlewares)+len(mws))
+	middlewares = append(middlewares, r.middlewares...)
+	middlewares = append(middlewares, mws...)
+
+	return &Router{
+		container:   r.container,
+		prefix:      r.prefix + prefix,
+		middlewares: middlewares,
+		routes:      r.routes,
+	}
+}
+
+// Handle 注册一个路由，handler 会先经过分组继承下来的中间件，再经过 decors 修饰，
+// path 中形如 :id 或 {id} 的分段会在请求到达时被解析为路径参数
+func (r *Router) Handle(method string, path string, handler WebHandler, decors ...HandlerDecorator) *RouteInfo {
+	fullPath := r.prefix + path
+	wrapped := applyMiddlewares(handler, r.middlewares)
+
+	info := &RouteInfo{
+		Method:   method,
+		Path:     fullPath,
+		segments: splitPath(fullPath),
+		handler:  NewWebHandler(r.container, wrapped, decors...),
+	}
+
+	*r.routes = append(*r.routes, info)
+
+	return info
+}
+
+// Routes 返回当前 Router（以及所有由它派生出的分组）注册过的全部路由信息
+func (r *Router) Routes() []*RouteInfo {
+	return *r.routes
+}
+
+// GET 注册一个 GET 路由
+func (r *Router) GET(path string, handler WebHandler, decors ...HandlerDecorator) *RouteInfo {
+	return r.Handle(http.MethodGet, path, handler, decors...)
+}
+
+// POST 注册一个 POST 路由
+func (r *Router) POST(path string, handler WebHandler, decors ...HandlerDecorator) *RouteInfo {
+	return r.Handle(http.MethodPost, path, handler, decors...)
+}
+
+// PUT 注册一个 PUT 路由
+func (r *Router) PUT(path string, handler WebHandler, decors ...HandlerDecorator) *RouteInfo {
+	return r.Handle(http.MethodPut, path, handler, decors...)
+}
+
+// DELETE 注册一个 DELETE 路由
+func (r *Router) DELETE(path string, handler WebHandler, decors ...HandlerDecorator) *RouteInfo {
+	return r.Handle(http.MethodDelete, path, handler, decors...)
+}
+
+// Inject 注册一个路由，与 Handle 不同的是，handler 是一个由 Container 负责注入
+// 参数的回调函数（与 WebContext.Resolve 接受的回调形式一致），其参数、返回值类型
+// 会被记录下来，供 OpenAPI 文档生成器反射使用
+func (r *Router) Inject(method string, path string, callback interface{}, decors ...HandlerDecorator) *RouteInfo {
+	info := r.Handle(method, path, func(ctx *WebContext) HTTPResponse {
+		if err := bindRequestDTOs(ctx, callback); err != nil {
+			if IsBindError(err) {
+				return ctx.NewErrorResponse(err.Error(), http.StatusBadRequest)
+			}
+
+			return ctx.NewErrorResponse(err.Error(), http.StatusInternalServerError)
+		}
+
+		return ctx.Resolve(callback)
+	}, decors...)
+
+	info.callback = callback
+
+	return info
+}
+
+// RouteInfo 记录了一次路由注册的基本信息，Doc/Tag 等方法用于追加文档元数据，
+// 供 OpenAPI 生成器使用
+type RouteInfo struct {
+	Method  string
+	Path    string
+	Summary string
+	Tags    []string
+
+	callback interface{}
+	segments []string
+	handler  webHandler
+}
+
+// Doc 设置该路由的文档摘要
+func (info *RouteInfo) Doc(summary string) *RouteInfo {
+	info.Summary = summary
+	return info
+}
+
+// Tag 为该路由追加一个或多个 OpenAPI 分组标签
+func (info *RouteInfo) Tag(tags ...string) *RouteInfo {
+	info.Tags = append(info.Tags, tags...)
+	return info
+}
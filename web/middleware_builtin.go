@@ -0,0 +1,287 @@
+package web
+
+import (
+	"compress/gzip"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestIDHeader 是请求 ID 在 HTTP 头中使用的字段名
+const RequestIDHeader = "X-Request-Id"
+
+// requestIDKey 是请求 ID 写入 Container 时使用的 key
+type requestIDKey struct{}
+
+// RecoveryMiddleware 捕获 handler 执行过程中的 panic，避免单个请求拖垮整个进程，
+// 捕获到的 panic 会被转换为 500 错误响应
+type RecoveryMiddleware struct {
+	BaseMiddleware
+}
+
+// Around 用 defer-recover 包裹 next handler
+func (m RecoveryMiddleware) Around(next WebHandler) WebHandler {
+	return func(ctx *WebContext) (resp HTTPResponse) {
+		defer func() {
+			if err := recover(); err != nil {
+				resp = ctx.NewErrorResponse(fmt.Sprintf("panic recovered: %v", err), http.StatusInternalServerError)
+			}
+		}()
+
+		return next(ctx)
+	}
+}
+
+// RequestIDMiddleware 为每一个请求生成（或透传）一个唯一的请求 ID，并写入
+// WebContext.Container，同时回写到响应头中方便链路追踪
+type RequestIDMiddleware struct {
+	BaseMiddleware
+
+	// Generator 用于生成请求 ID，默认使用当前时间戳拼接自增序号
+	Generator func() string
+}
+
+var requestIDSeq uint64
+var requestIDSeqLock sync.Mutex
+
+func defaultRequestID() string {
+	requestIDSeqLock.Lock()
+	defer requestIDSeqLock.Unlock()
+
+	requestIDSeq++
+	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), requestIDSeq)
+}
+
+// Before 解析请求头中已有的请求 ID，没有则生成一个新的，并注入 Container 供后续使用
+func (m RequestIDMiddleware) Before(ctx *WebContext) error {
+	id := ctx.Request.r.Header.Get(RequestIDHeader)
+	if id == "" {
+		generator := m.Generator
+		if generator == nil {
+			generator = defaultRequestID
+		}
+
+		id = generator()
+	}
+
+	ctx.Response.Header(RequestIDHeader, id)
+
+	return ctx.Container.BindValue(requestIDKey{}, id)
+}
+
+// RequestID 从 WebContext 中取出本次请求的请求 ID
+func RequestID(ctx *WebContext) string {
+	id, err := ctx.Container.Get(requestIDKey{})
+	if err != nil {
+		return ""
+	}
+
+	return id.(string)
+}
+
+// AccessLogMiddleware 记录每一次请求的方法、路径、请求 ID 与耗时等结构化访问日志，
+// 日志中不包含响应状态码
+type AccessLogMiddleware struct {
+	BaseMiddleware
+
+	// Logger 默认使用标准库 log.Printf 输出，可以替换为自定义的日志实现
+	Logger func(ctx *WebContext, cost time.Duration)
+}
+
+// Around 记录请求处理耗时，并在处理完成后输出访问日志
+func (m AccessLogMiddleware) Around(next WebHandler) WebHandler {
+	return func(ctx *WebContext) HTTPResponse {
+		startedAt := time.Now()
+		resp := next(ctx)
+		cost := time.Since(startedAt)
+
+		if m.Logger != nil {
+			m.Logger(ctx, cost)
+		} else {
+			log.Printf("[access] %s %s requestId=%s cost=%s", ctx.Request.r.Method, ctx.Request.r.URL.Path, RequestID(ctx), cost)
+		}
+
+		return resp
+	}
+}
+
+// GzipMiddleware 在客户端声明支持 gzip 时，对响应体进行压缩
+type GzipMiddleware struct {
+	BaseMiddleware
+}
+
+// gzipWriterKey 是 gzip.Writer 注册到当前请求子容器时使用的 key
+type gzipWriterKey struct{}
+
+// Around 在 Accept-Encoding 包含 gzip 时替换底层 ResponseWriter 为一个压缩写入器。
+// 真正的响应体写入发生在 resp.CreateResponse() 里，而这是在整条中间件链
+// （包括这里、以及任何 After 钩子）都返回之后，由 webHandler.serve 才调用的，
+// 所以这个 gzip.Writer 不能在这里、也不能在 After 钩子里关闭——那样做都早于实际
+// 写入，会导致已经声明了 Content-Encoding: gzip 的响应体为空或损坏。把它注册为
+// 当前请求子容器里的一个值，交给该容器在 CreateResponse 执行完之后的 Close()
+// （见 webHandler.serve 里的 defer）去关闭，时机才是对的
+func (m GzipMiddleware) Around(next WebHandler) WebHandler {
+	return func(ctx *WebContext) HTTPResponse {
+		if !acceptsGzip(ctx.Request.r.Header.Get("Accept-Encoding")) {
+			return next(ctx)
+		}
+
+		gw := gzip.NewWriter(ctx.Response.w)
+
+		ctx.Response.Header("Content-Encoding", "gzip")
+		ctx.Response.w = &gzipResponseWriter{ResponseWriter: ctx.Response.w, writer: gw}
+
+		_ = ctx.Container.BindValue(gzipWriterKey{}, gw)
+
+		return next(ctx)
+	}
+}
+
+func acceptsGzip(acceptEncoding string) bool {
+	return acceptEncoding != "" && (acceptEncoding == "*" || containsToken(acceptEncoding, "gzip"))
+}
+
+func containsToken(header string, token string) bool {
+	for _, part := range splitComma(header) {
+		if part == token {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitComma(s string) []string {
+	var parts []string
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == ',' {
+			parts = append(parts, trimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	parts = append(parts, trimSpace(s[start:]))
+
+	return parts
+}
+
+func trimSpace(s string) string {
+	for len(s) > 0 && s[0] == ' ' {
+		s = s[1:]
+	}
+
+	for len(s) > 0 && s[len(s)-1] == ' ' {
+		s = s[:len(s)-1]
+	}
+
+	return s
+}
+
+// gzipResponseWriter 将写入的内容转发给一个 gzip.Writer
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	writer *gzip.Writer
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	return w.writer.Write(b)
+}
+
+// CORSMiddleware 为响应附加跨域相关的响应头，OPTIONS 预检请求直接短路返回
+type CORSMiddleware struct {
+	BaseMiddleware
+
+	AllowOrigin  string
+	AllowMethods string
+	AllowHeaders string
+}
+
+// Around 附加 CORS 响应头，并在遇到 OPTIONS 预检请求时短路后续处理链
+func (m CORSMiddleware) Around(next WebHandler) WebHandler {
+	return func(ctx *WebContext) HTTPResponse {
+		origin := m.AllowOrigin
+		if origin == "" {
+			origin = "*"
+		}
+
+		ctx.Response.Header("Access-Control-Allow-Origin", origin)
+		ctx.Response.Header("Access-Control-Allow-Methods", orDefault(m.AllowMethods, "GET, POST, PUT, DELETE, OPTIONS"))
+		ctx.Response.Header("Access-Control-Allow-Headers", orDefault(m.AllowHeaders, "Content-Type, Authorization"))
+
+		if ctx.Request.r.Method == http.MethodOptions {
+			return ctx.NewRawResponse()
+		}
+
+		return next(ctx)
+	}
+}
+
+func orDefault(v string, def string) string {
+	if v == "" {
+		return def
+	}
+
+	return v
+}
+
+// RateLimitMiddleware 基于令牌桶对请求进行限流，超出限制时返回 429
+type RateLimitMiddleware struct {
+	BaseMiddleware
+
+	// Limit 是每个 Interval 内允许通过的请求数
+	Limit int
+	// Interval 是令牌桶重置的周期
+	Interval time.Duration
+
+	lock     sync.Mutex
+	tokens   int
+	resetAt  time.Time
+	initOnce sync.Once
+}
+
+func (m *RateLimitMiddleware) init() {
+	m.tokens = m.Limit
+	m.resetAt = time.Now().Add(m.Interval)
+}
+
+// Before 尝试从令牌桶中取出一个令牌，取不到则中断请求处理
+func (m *RateLimitMiddleware) Before(ctx *WebContext) error {
+	m.initOnce.Do(m.init)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	if now.After(m.resetAt) {
+		m.tokens = m.Limit
+		m.resetAt = now.Add(m.Interval)
+	}
+
+	if m.tokens <= 0 {
+		return ErrRateLimited("rate limit exceeded")
+	}
+
+	m.tokens--
+
+	return nil
+}
+
+// rateLimitError 实现了 HTTPStatusError，让 applyMiddlewares 把限流拒绝转换成
+// 429 Too Many Requests，而不是其他 Before 错误默认使用的 400
+type rateLimitError struct {
+	msg string
+}
+
+func (e *rateLimitError) Error() string { return e.msg }
+
+// StatusCode 实现 HTTPStatusError
+func (e *rateLimitError) StatusCode() int { return http.StatusTooManyRequests }
+
+// ErrRateLimited 表示请求被限流中间件拒绝，对应 429 Too Many Requests
+func ErrRateLimited(msg string) error {
+	return &rateLimitError{msg: fmt.Sprintf("rate limited: %s", msg)}
+}
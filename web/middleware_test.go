@@ -0,0 +1,171 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mylxsw/go-toolkit/container"
+)
+
+func TestSplitPath(t *testing.T) {
+	cases := map[string][]string{
+		"/":         nil,
+		"":          nil,
+		"/users":    {"users"},
+		"/users/42": {"users", "42"},
+		"users/42/": {"users", "42"},
+	}
+
+	for path, expect := range cases {
+		got := splitPath(path)
+		if len(got) != len(expect) {
+			t.Fatalf("splitPath(%q) = %v, want %v", path, got, expect)
+		}
+
+		for i := range expect {
+			if got[i] != expect[i] {
+				t.Fatalf("splitPath(%q) = %v, want %v", path, got, expect)
+			}
+		}
+	}
+}
+
+func TestMatchSegmentsCapturesColonAndBraceParams(t *testing.T) {
+	params, ok := matchSegments([]string{"users", ":id"}, []string{"users", "42"})
+	if !ok || params["id"] != "42" {
+		t.Fatalf("expected :id to capture 42, got %v, ok=%v", params, ok)
+	}
+
+	params, ok = matchSegments([]string{"users", "{id}"}, []string{"users", "42"})
+	if !ok || params["id"] != "42" {
+		t.Fatalf("expected {id} to capture 42, got %v, ok=%v", params, ok)
+	}
+
+	if _, ok := matchSegments([]string{"users", "42"}, []string{"users", "43"}); ok {
+		t.Fatal("expected literal segments to require an exact match")
+	}
+
+	if _, ok := matchSegments([]string{"users", ":id"}, []string{"users"}); ok {
+		t.Fatal("expected segment count mismatch to fail matching")
+	}
+}
+
+func TestRouterMatchesRegisteredPathParameterRoute(t *testing.T) {
+	router := NewRouter(container.New())
+	router.GET("/users/:id", func(ctx *WebContext) HTTPResponse { return ctx.NewRawResponse() })
+
+	route, params, ok := router.match(http.MethodGet, "/users/42")
+	if !ok {
+		t.Fatal("expected /users/42 to match the registered /users/:id route")
+	}
+
+	if params["id"] != "42" {
+		t.Fatalf("expected path param id=42, got %v", params)
+	}
+
+	if route.Path != "/users/:id" {
+		t.Fatalf("unexpected matched route: %v", route.Path)
+	}
+}
+
+func TestRouterServeHTTPPopulatesPathParams(t *testing.T) {
+	router := NewRouter(container.New())
+
+	var captured string
+	router.GET("/users/:id", func(ctx *WebContext) HTTPResponse {
+		type dto struct {
+			ID string `path:"id"`
+		}
+
+		var d dto
+		if err := ctx.Bind(&d); err != nil {
+			t.Fatalf("unexpected bind error: %s", err)
+		}
+
+		captured = d.ID
+
+		return ctx.NewRawResponse()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if captured != "42" {
+		t.Fatalf("expected path tag to bind id=42 from the matched route, got %q", captured)
+	}
+}
+
+func TestRouterServeHTTPReturnsNotFoundForUnmatchedPath(t *testing.T) {
+	router := NewRouter(container.New())
+	router.GET("/users/:id", func(ctx *WebContext) HTTPResponse { return ctx.NewRawResponse() })
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unmatched path, got %d", rec.Code)
+	}
+}
+
+type recordingMiddleware struct {
+	BaseMiddleware
+
+	name  string
+	calls *[]string
+}
+
+func (m recordingMiddleware) Before(ctx *WebContext) error {
+	*m.calls = append(*m.calls, m.name+":before")
+	return nil
+}
+
+func (m recordingMiddleware) After(ctx *WebContext, resp HTTPResponse) HTTPResponse {
+	*m.calls = append(*m.calls, m.name+":after")
+	return resp
+}
+
+func TestApplyMiddlewaresRunsInOnionOrder(t *testing.T) {
+	var calls []string
+
+	handler := applyMiddlewares(func(ctx *WebContext) HTTPResponse {
+		calls = append(calls, "handler")
+		return ctx.NewRawResponse()
+	}, []Middleware{
+		recordingMiddleware{name: "outer", calls: &calls},
+		recordingMiddleware{name: "inner", calls: &calls},
+	})
+
+	ctx := &WebContext{
+		Request:   &Request{r: httptest.NewRequest(http.MethodGet, "/", nil)},
+		Response:  &Response{w: httptest.NewRecorder(), headers: make(map[string]string)},
+		Container: container.New(),
+	}
+
+	handler(ctx)
+
+	want := []string{"outer:before", "inner:before", "handler", "inner:after", "outer:after"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("calls = %v, want %v", calls, want)
+		}
+	}
+}
+
+func TestStatusCodeOfFallsBackToBadRequest(t *testing.T) {
+	if statusCodeOf(ErrBind("bad input")) != http.StatusBadRequest {
+		t.Fatal("expected a plain error to map to 400 Bad Request")
+	}
+
+	if statusCodeOf(ErrRateLimited("too fast")) != http.StatusTooManyRequests {
+		t.Fatal("expected a rate-limit error to map to its own StatusCode()")
+	}
+}
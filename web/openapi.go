@@ -0,0 +1,204 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// OpenAPISpec 是生成的 OpenAPI 3.0 文档，字段按照规范中实际用到的子集定义，
+// 没有覆盖的扩展字段可以直接忽略
+type OpenAPISpec struct {
+	OpenAPI string                          `json:"openapi"`
+	Info    OpenAPIInfo                     `json:"info"`
+	Paths   map[string]map[string]OpenAPIOp `json:"paths"`
+}
+
+// OpenAPIInfo 对应 OpenAPI 文档的 info 节点
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIOp 描述了单个路径 + 方法组合下的操作
+type OpenAPIOp struct {
+	Summary     string                 `json:"summary,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Parameters  []OpenAPIParam         `json:"parameters,omitempty"`
+	RequestBody map[string]interface{} `json:"requestBody,omitempty"`
+	Responses   map[string]interface{} `json:"responses"`
+}
+
+// OpenAPIParam 描述了一个来自 path/query/header 的参数
+type OpenAPIParam struct {
+	Name     string            `json:"name"`
+	In       string            `json:"in"`
+	Required bool              `json:"required,omitempty"`
+	Schema   map[string]string `json:"schema"`
+}
+
+// NewOpenAPISpec 反射遍历 router 已注册的路由（通过 Inject 注册、携带回调类型信息的
+// 那些），结合 Doc/Tag 附加的元数据以及 bind 标签，生成一份 OpenAPI 3.0 文档
+func NewOpenAPISpec(title, version string, router *Router) *OpenAPISpec {
+	spec := &OpenAPISpec{
+		OpenAPI: "3.0.0",
+		Info:    OpenAPIInfo{Title: title, Version: version},
+		Paths:   make(map[string]map[string]OpenAPIOp),
+	}
+
+	for _, route := range router.Routes() {
+		if route.callback == nil {
+			continue
+		}
+
+		op := OpenAPIOp{
+			Summary:   route.Summary,
+			Tags:      route.Tags,
+			Responses: map[string]interface{}{"200": map[string]string{"description": "OK"}},
+		}
+
+		op.Parameters, op.RequestBody = reflectCallback(route.callback)
+
+		if _, ok := spec.Paths[route.Path]; !ok {
+			spec.Paths[route.Path] = make(map[string]OpenAPIOp)
+		}
+
+		spec.Paths[route.Path][methodToOpenAPI(route.Method)] = op
+	}
+
+	return spec
+}
+
+func methodToOpenAPI(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "get"
+	case http.MethodPost:
+		return "post"
+	case http.MethodPut:
+		return "put"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "get"
+	}
+}
+
+// reflectCallback 反射回调函数的入参，struct 类型的参数会按照 bind 标签拆解为
+// path/query/header 参数；没有命中上述标签、但带有 json 标签的字段作为 JSON
+// 请求体的属性，其余字段被忽略
+func reflectCallback(callback interface{}) ([]OpenAPIParam, map[string]interface{}) {
+	t := reflect.TypeOf(callback)
+	if t == nil || t.Kind() != reflect.Func {
+		return nil, nil
+	}
+
+	var params []OpenAPIParam
+	var body map[string]interface{}
+
+	for i := 0; i < t.NumIn(); i++ {
+		argType := t.In(i)
+		for argType.Kind() == reflect.Ptr {
+			argType = argType.Elem()
+		}
+
+		if argType.Kind() != reflect.Struct {
+			continue
+		}
+
+		bodyProps := map[string]interface{}{}
+
+		for f := 0; f < argType.NumField(); f++ {
+			field := argType.Field(f)
+
+			if name, ok := field.Tag.Lookup("path"); ok {
+				params = append(params, OpenAPIParam{Name: name, In: "path", Required: true, Schema: schemaOf(field.Type)})
+				continue
+			}
+
+			if name, ok := field.Tag.Lookup("query"); ok {
+				params = append(params, OpenAPIParam{Name: name, In: "query", Schema: schemaOf(field.Type)})
+				continue
+			}
+
+			if name, ok := field.Tag.Lookup("header"); ok {
+				params = append(params, OpenAPIParam{Name: name, In: "header", Schema: schemaOf(field.Type)})
+				continue
+			}
+
+			if name, ok := field.Tag.Lookup("json"); ok {
+				bodyProps[name] = schemaOf(field.Type)
+			}
+		}
+
+		if len(bodyProps) > 0 {
+			body = map[string]interface{}{
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": map[string]interface{}{
+							"type":       "object",
+							"properties": bodyProps,
+						},
+					},
+				},
+			}
+		}
+	}
+
+	return params, body
+}
+
+func schemaOf(t reflect.Type) map[string]string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]string{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]string{"type": "number"}
+	case reflect.Bool:
+		return map[string]string{"type": "boolean"}
+	default:
+		return map[string]string{"type": "string"}
+	}
+}
+
+const swaggerUITemplate = `<!DOCTYPE html>
+<html>
+<head>
+  <title>%s</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({url: %q, dom_id: '#swagger-ui'})
+    }
+  </script>
+</body>
+</html>`
+
+// ServeOpenAPI 在 router 上注册两个路由：specPath 返回 JSON 格式的 OpenAPI 文档，
+// uiPath 返回一个内嵌 Swagger UI 的 HTML 页面
+func ServeOpenAPI(router *Router, title, version, specPath, uiPath string) {
+	router.GET(specPath, func(ctx *WebContext) HTTPResponse {
+		spec := NewOpenAPISpec(title, version, router)
+
+		data, err := json.Marshal(spec)
+		if err != nil {
+			return ctx.NewErrorResponse(fmt.Sprintf("marshal openapi spec failed: %s", err), http.StatusInternalServerError)
+		}
+
+		resp := ctx.NewRawResponse()
+		ctx.Response.Header("Content-Type", "application/json")
+		resp.Write(data)
+
+		return resp
+	})
+
+	router.GET(uiPath, func(ctx *WebContext) HTTPResponse {
+		return ctx.NewHTMLResponse(fmt.Sprintf(swaggerUITemplate, title, specPath))
+	})
+}
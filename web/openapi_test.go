@@ -0,0 +1,102 @@
+package web
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/mylxsw/go-toolkit/container"
+)
+
+type getUserRequest struct {
+	ID   string `path:"id"`
+	Lang string `query:"lang"`
+}
+
+func TestReflectCallbackExtractsPathAndQueryParams(t *testing.T) {
+	params, body := reflectCallback(func(req getUserRequest) (string, error) { return "", nil })
+
+	if body != nil {
+		t.Fatalf("expected no request body for a path/query-only DTO, got %v", body)
+	}
+
+	var sawID, sawLang bool
+	for _, p := range params {
+		switch p.Name {
+		case "id":
+			sawID = true
+			if p.In != "path" || !p.Required {
+				t.Fatalf("expected id to be a required path param, got %+v", p)
+			}
+		case "lang":
+			sawLang = true
+			if p.In != "query" {
+				t.Fatalf("expected lang to be a query param, got %+v", p)
+			}
+		}
+	}
+
+	if !sawID || !sawLang {
+		t.Fatalf("expected both id and lang to be reflected, got %v", params)
+	}
+}
+
+type createUserRequest struct {
+	Name string `json:"name"`
+}
+
+func TestReflectCallbackBuildsJSONRequestBody(t *testing.T) {
+	_, body := reflectCallback(func(req createUserRequest) error { return nil })
+
+	if body == nil {
+		t.Fatal("expected a json-tagged struct arg to produce a request body")
+	}
+}
+
+func TestNewOpenAPISpecSkipsRoutesWithoutInjectCallback(t *testing.T) {
+	router := NewRouter(container.New())
+	router.GET("/plain", func(ctx *WebContext) HTTPResponse { return ctx.NewRawResponse() })
+	router.Inject(http.MethodGet, "/users/:id", func(req getUserRequest) (string, error) { return "", nil })
+
+	spec := NewOpenAPISpec("test", "v1", router)
+
+	if _, ok := spec.Paths["/plain"]; ok {
+		t.Fatal("expected a plain Handle-registered route without callback metadata to be skipped")
+	}
+
+	op, ok := spec.Paths["/users/:id"]["get"]
+	if !ok {
+		t.Fatal("expected the Inject-registered route to appear in the spec")
+	}
+
+	if len(op.Parameters) != 2 {
+		t.Fatalf("expected 2 reflected parameters, got %d: %+v", len(op.Parameters), op.Parameters)
+	}
+}
+
+func TestSchemaOfMapsGoKindsToOpenAPITypes(t *testing.T) {
+	type sample struct {
+		Count int
+		Price float64
+		OK    bool
+		Name  string
+	}
+
+	rt := reflect.TypeOf(sample{})
+
+	if schemaOf(rt.Field(0).Type)["type"] != "integer" {
+		t.Fatal("expected int to map to integer schema")
+	}
+
+	if schemaOf(rt.Field(1).Type)["type"] != "number" {
+		t.Fatal("expected float64 to map to number schema")
+	}
+
+	if schemaOf(rt.Field(2).Type)["type"] != "boolean" {
+		t.Fatal("expected bool to map to boolean schema")
+	}
+
+	if schemaOf(rt.Field(3).Type)["type"] != "string" {
+		t.Fatal("expected string to map to string schema")
+	}
+}
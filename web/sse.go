@@ -0,0 +1,102 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// sseKeepAliveInterval 是向客户端发送保活注释的固定周期，期间是否推送过事件不影响该周期
+const sseKeepAliveInterval = 15 * time.Second
+
+// Event 是一条 SSE（Server-Sent Events）消息，对应 text/event-stream 格式里的一个事件块
+type Event struct {
+	// ID 对应 id 字段，客户端断线重连时会通过 Last-Event-ID 请求头带回该值
+	ID string
+	// Event 对应 event 字段，缺省时客户端按 message 事件处理
+	Event string
+	// Data 对应 data 字段，多行内容会被拆分为多个 data: 行
+	Data string
+}
+
+// SSEResponse 以 text/event-stream 的形式持续向客户端推送 ch 中的事件，直到 ch 关闭
+// 或者客户端断开连接
+type SSEResponse struct {
+	response *Response
+	request  *Request
+	ch       <-chan Event
+}
+
+// NewSSEResponse 创建一个 SSEResponse
+func NewSSEResponse(response *Response, request *Request, ch <-chan Event) SSEResponse {
+	return SSEResponse{response: response, request: request, ch: ch}
+}
+
+// NewSSEResponse 在 WebContext 上创建一个 SSE 响应
+func (ctx *WebContext) NewSSEResponse(ch <-chan Event) SSEResponse {
+	return NewSSEResponse(ctx.Response, ctx.Request, ch)
+}
+
+// LastEventID 读取客户端重连时携带的 Last-Event-ID 请求头，调用方可以据此从断点
+// 之后继续推送事件
+func (ctx *WebContext) LastEventID() string {
+	return ctx.Request.r.Header.Get("Last-Event-ID")
+}
+
+// CreateResponse 实现 HTTPResponse 接口：持续写出 ch 中的事件，并按固定周期发送保活注释
+func (resp SSEResponse) CreateResponse() {
+	w := resp.response.w
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "web: streaming not supported by the underlying ResponseWriter", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(sseKeepAliveInterval)
+	defer ticker.Stop()
+
+	ctx := resp.request.r.Context()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-resp.ch:
+			if !ok {
+				return
+			}
+
+			writeSSEEvent(w, event)
+			flusher.Flush()
+
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSSEEvent(w http.ResponseWriter, event Event) {
+	if event.ID != "" {
+		fmt.Fprintf(w, "id: %s\n", event.ID)
+	}
+
+	if event.Event != "" {
+		fmt.Fprintf(w, "event: %s\n", event.Event)
+	}
+
+	for _, line := range strings.Split(event.Data, "\n") {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+
+	fmt.Fprint(w, "\n")
+}
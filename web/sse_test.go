@@ -0,0 +1,28 @@
+package web
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteSSEEventFormatsIDEventAndMultilineData(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	writeSSEEvent(rec, Event{ID: "1", Event: "update", Data: "line1\nline2"})
+
+	want := "id: 1\nevent: update\ndata: line1\ndata: line2\n\n"
+	if rec.Body.String() != want {
+		t.Fatalf("writeSSEEvent wrote %q, want %q", rec.Body.String(), want)
+	}
+}
+
+func TestWriteSSEEventOmitsEmptyIDAndEvent(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	writeSSEEvent(rec, Event{Data: "hello"})
+
+	want := "data: hello\n\n"
+	if rec.Body.String() != want {
+		t.Fatalf("writeSSEEvent wrote %q, want %q", rec.Body.String(), want)
+	}
+}
@@ -0,0 +1,229 @@
+package web
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// websocketMagic 是 RFC 6455 中定义的、用于计算握手响应的固定 GUID
+const websocketMagic = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket 帧的 opcode，参见 RFC 6455 第 11.8 节
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+)
+
+// WSConn 是升级之后的 WebSocket 连接，提供按消息收发的简化接口。当前实现只支持
+// 非分片帧（FIN=1），这对绝大多数客户端实现已经足够
+type WSConn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+}
+
+// ReadMessage 读取一个完整的 WebSocket 消息，返回其 opcode 与负载
+func (c *WSConn) ReadMessage() (messageType int, data []byte, err error) {
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if !fin {
+			return 0, nil, errors.New("web: fragmented websocket frames are not supported")
+		}
+
+		switch opcode {
+		case PingMessage:
+			if err := c.WriteMessage(PongMessage, payload); err != nil {
+				return 0, nil, err
+			}
+
+			continue
+		case PongMessage:
+			continue
+		default:
+			return opcode, payload, nil
+		}
+	}
+}
+
+func (c *WSConn) readFrame() (fin bool, opcode int, payload []byte, err error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(c.rw, header); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin = header[0]&0x80 != 0
+	opcode = int(header[0] & 0x0f)
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(c.rw, ext); err != nil {
+			return false, 0, nil, err
+		}
+
+		length = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := io.ReadFull(c.rw, ext); err != nil {
+			return false, 0, nil, err
+		}
+
+		length = binary.BigEndian.Uint64(ext)
+	}
+
+	var maskKey [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.rw, maskKey[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.rw, payload); err != nil {
+		return false, 0, nil, err
+	}
+
+	if masked {
+		for i := range payload {
+			payload[i] ^= maskKey[i%4]
+		}
+	}
+
+	if opcode == CloseMessage {
+		return fin, opcode, payload, io.EOF
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// WriteMessage 发送一个完整的、非分片的 WebSocket 消息。服务端发出的帧不做掩码处理
+func (c *WSConn) WriteMessage(messageType int, data []byte) error {
+	header := []byte{0x80 | byte(messageType)}
+
+	switch {
+	case len(data) <= 125:
+		header = append(header, byte(len(data)))
+	case len(data) <= 0xffff:
+		ext := make([]byte, 2)
+		binary.BigEndian.PutUint16(ext, uint16(len(data)))
+		header = append(header, 126)
+		header = append(header, ext...)
+	default:
+		ext := make([]byte, 8)
+		binary.BigEndian.PutUint64(ext, uint64(len(data)))
+		header = append(header, 127)
+		header = append(header, ext...)
+	}
+
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+
+	if _, err := c.rw.Write(data); err != nil {
+		return err
+	}
+
+	return c.rw.Flush()
+}
+
+// Close 发送关闭帧并关闭底层连接
+func (c *WSConn) Close() error {
+	_ = c.WriteMessage(CloseMessage, nil)
+	return c.conn.Close()
+}
+
+// WebSocketResponse 接管底层连接，完成 WebSocket 握手后将控制权交给 handler，
+// handler 返回（或连接断开）之后连接被关闭
+type WebSocketResponse struct {
+	response *Response
+	request  *Request
+	handler  func(*WSConn) error
+}
+
+// NewWebSocketResponse 创建一个 WebSocketResponse
+func NewWebSocketResponse(response *Response, request *Request, handler func(*WSConn) error) WebSocketResponse {
+	return WebSocketResponse{response: response, request: request, handler: handler}
+}
+
+// NewWebSocketResponse 在 WebContext 上注册一个 WebSocket 升级响应，handler 在握手
+// 完成之后运行，返回值非 nil 时仅用于记录日志，连接始终会在 handler 结束后关闭
+func (ctx *WebContext) NewWebSocketResponse(handler func(*WSConn) error) WebSocketResponse {
+	return NewWebSocketResponse(ctx.Response, ctx.Request, handler)
+}
+
+// CreateResponse 实现 HTTPResponse 接口：完成 WebSocket 握手并接管连接
+func (resp WebSocketResponse) CreateResponse() {
+	r := resp.request.r
+
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || !strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+		http.Error(resp.response.w, "web: expect websocket upgrade request", http.StatusBadRequest)
+		return
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(resp.response.w, "web: missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return
+	}
+
+	hijacker, ok := resp.response.w.(http.Hijacker)
+	if !ok {
+		http.Error(resp.response.w, "web: the underlying ResponseWriter does not support hijacking", http.StatusInternalServerError)
+		return
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		http.Error(resp.response.w, fmt.Sprintf("web: hijack connection failed: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	accept := computeAcceptKey(key)
+
+	handshake := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n"
+
+	if _, err := rw.WriteString(handshake); err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	if err := rw.Flush(); err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	wsConn := &WSConn{conn: conn, rw: rw}
+	defer wsConn.Close()
+
+	if err := resp.handler(wsConn); err != nil {
+		log.Printf("[websocket] handler returned error: %s", err)
+	}
+}
+
+func computeAcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key))
+	h.Write([]byte(websocketMagic))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
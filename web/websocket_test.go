@@ -0,0 +1,13 @@
+package web
+
+import "testing"
+
+// TestComputeAcceptKey uses the worked example from RFC 6455 section 1.3.
+func TestComputeAcceptKey(t *testing.T) {
+	got := computeAcceptKey("dGhlIHNhbXBsZSBub25jZQ==")
+	want := "s3pPLMBiTxaQ9kYGzzhZRbK+xOo="
+
+	if got != want {
+		t.Fatalf("computeAcceptKey() = %q, want %q", got, want)
+	}
+}